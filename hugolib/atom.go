@@ -0,0 +1,49 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"context"
+
+	"github.com/gohugoio/hugo/resources/page"
+)
+
+// renderAtomFeed renders the site's Atom 1.0 feed for the home page, if
+// the site has defined an "Atom" output format and referenced it from
+// outputs.home (see initializeSiteInfo). RSS gets its template found for
+// it by the per-page-kind rendering in renderPages, but Atom has no
+// built-in output.Format for that machinery to key off of, so this
+// renders it explicitly instead, the same way renderSitemap explicitly
+// renders the sitemap rather than relying on renderPages.
+func (s *Site) renderAtomFeed() error {
+	atomOutputFormat, found := s.outputFormats[page.KindHome].GetByName("Atom")
+	if !found {
+		return nil
+	}
+
+	templ := s.lookupLayouts("rss.atom.xml", "_default/rss.atom.xml", "_internal/_default/rss.atom.xml")
+	if templ == nil {
+		return nil
+	}
+
+	return s.renderAndWriteXML(
+		context.Background(),
+		&s.PathSpec.ProcessingStats.Pages,
+		"atom",
+		atomOutputFormat.BaseFilename(),
+		s.home.p,
+		templ,
+		atomOutputFormat,
+	)
+}
@@ -0,0 +1,63 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"encoding/json"
+	"io"
+)
+
+// buildEvent is one line of the newline-delimited JSON stream written to
+// the site's build event sink, if one is configured via
+// Site.SetBuildEventSink. It lets editors and CI wrappers subscribe to
+// what Hugo saw and did during a (partial) rebuild without scraping the
+// human-readable log.
+type buildEvent struct {
+	Phase string `json:"phase"`
+	Path  string `json:"path,omitempty"`
+	Op    string `json:"op,omitempty"`
+	Kept  bool   `json:"kept"`
+}
+
+// SetBuildEventSink configures w to receive the newline-delimited JSON
+// build-event stream for this Site. It has no effect on already-running
+// builds; call it after NewSite and before Build. Passing nil (the
+// default) disables event emission.
+//
+// The sink is set on the Site; deps.DepsCfg has no field for it.
+func (s *Site) SetBuildEventSink(w io.Writer) {
+	s.siteCfg.buildEventSink = w
+}
+
+// emitBuildEvent writes ev as a single line of JSON to the site's
+// configured build event sink. It is a no-op if no sink is configured.
+// Marshalling or write failures are logged but never fail the build.
+func (s *Site) emitBuildEvent(ev buildEvent) {
+	sink := s.siteCfg.buildEventSink
+	if sink == nil {
+		return
+	}
+
+	b, err := json.Marshal(ev)
+	if err != nil {
+		s.Log.Warnf("failed to marshal build event: %s", err)
+		return
+	}
+
+	b = append(b, '\n')
+
+	if _, err := sink.Write(b); err != nil {
+		s.Log.Warnf("failed to write build event: %s", err)
+	}
+}
@@ -0,0 +1,227 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"bytes"
+	"crypto/sha1"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"net/http"
+	"strings"
+
+	"github.com/gohugoio/hugo/helpers"
+	"github.com/spf13/afero"
+)
+
+// defaultIndexNowEndpoint is used when [services.indexnow].endpoint is
+// left unset in site configuration.
+const defaultIndexNowEndpoint = "https://api.indexnow.org/indexnow"
+
+// indexNowBatchSize is the largest urlList IndexNow accepts per request.
+const indexNowBatchSize = 10000
+
+// indexNowStateFile is where indexNowPublishedSince persists the
+// permalink->content-hash map from the previous build, when
+// [services.indexnow].changedOnly is set, so it can tell which URLs
+// actually changed rather than were merely rewritten. It's written to
+// the publish filesystem, next to the key file, so it is published with
+// the site.
+const indexNowStateFile = ".hugo_indexnow_state.json"
+
+// hashIndexNowContent returns a short content signature for b, used to
+// tell whether a page's rendered output actually changed between builds.
+func hashIndexNowContent(b []byte) string {
+	sum := sha1.Sum(b)
+	return hex.EncodeToString(sum[:])
+}
+
+// resetIndexNowPublished clears the set of pages recorded as published,
+// so each build only ever notifies IndexNow about pages it actually
+// wrote during that build. Called once per build, before any page is
+// rendered.
+func (s *Site) resetIndexNowPublished() {
+	s.siteCfg.indexNowMu.Lock()
+	defer s.siteCfg.indexNowMu.Unlock()
+	for k := range s.siteCfg.indexNowPublished {
+		delete(s.siteCfg.indexNowPublished, k)
+	}
+}
+
+// recordIndexNowPublish records permalink as having been written to disk
+// during the current build with the given rendered-content hash (see
+// hashIndexNowContent), so it's considered the next time
+// indexNowPublishedSince is called.
+func (s *Site) recordIndexNowPublish(permalink, contentHash string) {
+	s.siteCfg.indexNowMu.Lock()
+	defer s.siteCfg.indexNowMu.Unlock()
+	s.siteCfg.indexNowPublished[permalink] = contentHash
+}
+
+// indexNowPublishedSince returns the permalinks that should be notified
+// for the current build. Ordinarily that's every page published during
+// the build (see recordIndexNowPublish). When
+// [services.indexnow].changedOnly is set, a build publishes every page
+// from scratch regardless, so this instead diffs each page's rendered
+// content hash against the previous build's (persisted in
+// indexNowStateFile) and returns only the URLs that are new or whose
+// hash changed.
+func (s *Site) indexNowPublishedSince() []string {
+	s.siteCfg.indexNowMu.Lock()
+	current := make(map[string]string, len(s.siteCfg.indexNowPublished))
+	for permalink, hash := range s.siteCfg.indexNowPublished {
+		current[permalink] = hash
+	}
+	s.siteCfg.indexNowMu.Unlock()
+
+	if !s.siteCfg.indexNowChangedOnly {
+		urls := make([]string, 0, len(current))
+		for permalink := range current {
+			urls = append(urls, permalink)
+		}
+		return urls
+	}
+
+	previous := s.loadIndexNowState()
+
+	var urls []string
+	for permalink, hash := range current {
+		if previous[permalink] != hash {
+			urls = append(urls, permalink)
+		}
+	}
+
+	s.saveIndexNowState(current)
+
+	return urls
+}
+
+// loadIndexNowState reads the permalink->content-hash map persisted by
+// the previous build's saveIndexNowState. A missing or unreadable state
+// file is treated as "nothing published before", so the first
+// changedOnly build notifies every page once.
+func (s *Site) loadIndexNowState() map[string]string {
+	state := make(map[string]string)
+
+	b, err := afero.ReadFile(s.BaseFs.Publish.Fs, indexNowStateFile)
+	if err != nil {
+		return state
+	}
+
+	_ = json.Unmarshal(b, &state)
+
+	return state
+}
+
+// saveIndexNowState persists state for the next build's
+// loadIndexNowState to diff against.
+func (s *Site) saveIndexNowState(state map[string]string) {
+	b, err := json.Marshal(state)
+	if err != nil {
+		s.Log.Warnf("indexnow: failed to marshal changedOnly state: %s", err)
+		return
+	}
+
+	if err := helpers.WriteToDisk(indexNowStateFile, bytes.NewReader(b), s.BaseFs.Publish.Fs); err != nil {
+		s.Log.Warnf("indexnow: failed to persist changedOnly state: %s", err)
+	}
+}
+
+// notifyIndexNow tells search engines that support the IndexNow protocol
+// about urls, the set of absolute URLs whose target paths were actually
+// written during this build (see recordIndexNowPublish). It's opt-in: a
+// no-op unless both [services.indexnow].key is configured and the build
+// was run with --notify, so `hugo server` and ordinary builds never make
+// an outbound request by surprise. Every page published during the build
+// is notified, unless [services.indexnow].changedOnly is set, in which
+// case unchanged pages are left out (see indexNowPublishedSince). Any failure -- a bad key
+// file write, an unreachable endpoint -- is logged as a warning; being
+// unable to ping a third party is never a reason to fail a Hugo build.
+func (s *Site) notifyIndexNow(urls []string) {
+	key := s.siteCfg.indexNowKey
+	if key == "" || !s.conf.Internal.Notify {
+		return
+	}
+
+	keyLocation := s.siteCfg.indexNowKeyLocation
+
+	if err := s.publishIndexNowKeyFile(key, keyLocation); err != nil {
+		s.Log.Warnf("indexnow: failed to publish key file %q: %s", keyLocation, err)
+	}
+
+	if len(urls) == 0 {
+		return
+	}
+
+	endpoint := s.siteCfg.indexNowEndpoint
+	if endpoint == "" {
+		endpoint = defaultIndexNowEndpoint
+	}
+
+	host := s.PathSpec.BaseURL.URL().Host
+
+	for i := 0; i < len(urls); i += indexNowBatchSize {
+		end := i + indexNowBatchSize
+		if end > len(urls) {
+			end = len(urls)
+		}
+
+		if err := s.postIndexNowBatch(endpoint, host, key, keyLocation, urls[i:end]); err != nil {
+			s.Log.Warnf("indexnow: notify failed: %s", err)
+		}
+	}
+}
+
+func (s *Site) postIndexNowBatch(endpoint, host, key, keyLocation string, urls []string) error {
+	body, err := json.Marshal(struct {
+		Host        string   `json:"host"`
+		Key         string   `json:"key"`
+		KeyLocation string   `json:"keyLocation"`
+		URLList     []string `json:"urlList"`
+	}{
+		Host:        host,
+		Key:         key,
+		KeyLocation: keyLocation,
+		URLList:     urls,
+	})
+	if err != nil {
+		return err
+	}
+
+	req, err := http.NewRequest(http.MethodPost, endpoint, bytes.NewReader(body))
+	if err != nil {
+		return err
+	}
+	req.Header.Set("Content-Type", "application/json; charset=utf-8")
+
+	resp, err := http.DefaultClient.Do(req)
+	if err != nil {
+		return err
+	}
+	defer resp.Body.Close()
+
+	if resp.StatusCode >= 400 {
+		return fmt.Errorf("indexnow endpoint returned %s", resp.Status)
+	}
+
+	return nil
+}
+
+// publishIndexNowKeyFile writes the IndexNow key to keyLocation as a
+// regular site asset, so the endpoint can verify site ownership by
+// fetching it back.
+func (s *Site) publishIndexNowKeyFile(key, keyLocation string) error {
+	return helpers.WriteToDisk(keyLocation, strings.NewReader(key), s.BaseFs.Publish.Fs)
+}
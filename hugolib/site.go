@@ -25,6 +25,7 @@ import (
 	"runtime"
 	"sort"
 	"strings"
+	"sync"
 	"time"
 
 	"github.com/gohugoio/hugo/common/herrors"
@@ -94,6 +95,70 @@ type siteConfigHolder struct {
 	timeout          time.Duration
 	hasCJKLanguage   bool
 	enableEmoji      bool
+
+	// Default next/prev ordering strategy per section, keyed by section
+	// path (e.g. "blog"), as configured in site config under
+	// [navigation.sort]. A page can override this for its own section
+	// via a `navigation.sort` front matter field. See page.RegisterNextPrevSort.
+	navigationSort map[string]string
+
+	// taxonomyAliases holds, per plural, a canonical term name mapped to
+	// its configured synonyms, as decoded from [taxonomies.aliases] in
+	// site config. See page.TaxonomyProvider.
+	taxonomyAliases map[string]map[string][]string
+
+	// taxonomyProvider assembles and looks up taxonomy terms. It
+	// defaults to defaultTaxonomyProvider, but can be swapped out by
+	// anything implementing page.TaxonomyProvider.
+	taxonomyProvider page.TaxonomyProvider
+
+	// sitemapSplitThreshold and sitemapGzip are decoded from
+	// [sitemap].splitThreshold and [sitemap].gzip in site config. See
+	// renderSitemap.
+	sitemapSplitThreshold int
+	sitemapGzip           bool
+
+	// indexNowKey, indexNowKeyLocation, indexNowEndpoint and
+	// indexNowChangedOnly are decoded from [services.indexnow] in site
+	// config. See notifyIndexNow.
+	indexNowKey         string
+	indexNowKeyLocation string
+	indexNowEndpoint    string
+	indexNowChangedOnly bool
+
+	// indexNowMu guards indexNowPublished, the permalink->content-hash
+	// map of pages actually written to disk during the current build.
+	// See notifyIndexNow and recordIndexNowPublish.
+	indexNowMu        *sync.Mutex
+	indexNowPublished map[string]string
+
+	// watchDebounce is decoded from [internal].watchDebounce in site
+	// config. See bufferFileEvents.
+	watchDebounce time.Duration
+
+	// buildEventSink is where emitBuildEvent writes the newline-delimited
+	// JSON build-event stream, if set via Site.SetBuildEventSink. It is
+	// held per Site here; deps.DepsCfg has no field for it.
+	buildEventSink io.Writer
+}
+
+// nextPrevSortFor returns the configured next/prev ordering strategy name
+// for the given section page, checking the section's own front matter
+// before falling back to the site-wide per-section configuration.
+func (s *Site) nextPrevSortFor(sect page.Page) string {
+	if sect != nil {
+		if v, ok := sect.Params()["navigation"]; ok {
+			if m, ok := v.(maps.Params); ok {
+				if sort, ok := m["sort"].(string); ok && sort != "" {
+					return sort
+				}
+			}
+		}
+	}
+	if sect == nil {
+		return s.siteCfg.navigationSort["/"]
+	}
+	return s.siteCfg.navigationSort[sect.Section()]
 }
 
 // Lazily loaded site dependencies.
@@ -127,6 +192,7 @@ func (s *Site) prepareInits() {
 
 	s.init.prevNext = init.Branch(func(context.Context) (any, error) {
 		regularPages := s.RegularPages()
+		page.SortByStrategy(s.nextPrevSortFor(nil), regularPages)
 		for i, p := range regularPages {
 			np, ok := p.(nextPrevProvider)
 			if !ok {
@@ -190,7 +256,7 @@ func (s *Site) prepareInits() {
 			treeRef.m.collectPages(pageMapQuery{Prefix: treeRef.key + cmBranchSeparator}, func(c *contentNode) {
 				pas = append(pas, c.p)
 			})
-			page.SortByDefault(pas)
+			page.SortByStrategy(s.nextPrevSortFor(sect), pas)
 
 			setNextPrev(pas)
 		}
@@ -202,7 +268,7 @@ func (s *Site) prepareInits() {
 		treeRef.m.collectPages(pageMapQuery{Prefix: treeRef.key + cmBranchSeparator}, func(c *contentNode) {
 			pas = append(pas, c.p)
 		})
-		page.SortByDefault(pas)
+		page.SortByStrategy(s.nextPrevSortFor(nil), pas)
 
 		setNextPrev(pas)
 
@@ -215,7 +281,7 @@ func (s *Site) prepareInits() {
 	})
 
 	s.init.taxonomies = init.Branch(func(context.Context) (any, error) {
-		err := s.pageMap.assembleTaxonomies()
+		err := s.siteCfg.taxonomyProvider.Assemble()
 		return nil, err
 	})
 }
@@ -439,12 +505,74 @@ But this also means that your site configuration may not do what you expect. If
 		}
 	}
 
+	var navigationSort map[string]string
+	if cfg.Language.IsSet("navigation") {
+		navigationSort = cast.ToStringMapString(cfg.Language.GetStringMap("navigation")["sort"])
+	}
+
+	var taxonomyAliases map[string]map[string][]string
+	if cfg.Language.IsSet("taxonomies") {
+		if aliasesCfg, found := cfg.Language.GetStringMap("taxonomies")["aliases"]; found {
+			taxonomyAliases = make(map[string]map[string][]string)
+			for plural, v := range cast.ToStringMap(aliasesCfg) {
+				synonyms := make(map[string][]string)
+				for canonical, alts := range cast.ToStringMap(v) {
+					synonyms[canonical] = cast.ToStringSlice(alts)
+				}
+				taxonomyAliases[plural] = synonyms
+			}
+		}
+	}
+
+	var sitemapSplitThreshold int
+	var sitemapGzip bool
+	if cfg.Language.IsSet("sitemap") {
+		sitemapCfg := cfg.Language.GetStringMap("sitemap")
+		sitemapSplitThreshold = cast.ToInt(sitemapCfg["splitthreshold"])
+		sitemapGzip = cast.ToBool(sitemapCfg["gzip"])
+	}
+
+	var indexNowKey, indexNowKeyLocation, indexNowEndpoint string
+	var indexNowChangedOnly bool
+	if cfg.Language.IsSet("services") {
+		if indexNowCfg, found := cfg.Language.GetStringMap("services")["indexnow"]; found {
+			m := cast.ToStringMapString(indexNowCfg)
+			indexNowKey = m["key"]
+			indexNowKeyLocation = m["keylocation"]
+			indexNowEndpoint = m["endpoint"]
+			indexNowChangedOnly = cast.ToBool(m["changedonly"])
+			if indexNowKey != "" && indexNowKeyLocation == "" {
+				indexNowKeyLocation = "/" + indexNowKey + ".txt"
+			}
+		}
+	}
+
+	watchDebounce := defaultWatchDebounce
+	if cfg.Language.IsSet("internal") {
+		if v, found := cfg.Language.GetStringMap("internal")["watchdebounce"]; found {
+			if d, err := types.ToDurationE(v); err == nil {
+				watchDebounce = d
+			}
+		}
+	}
+
 	siteConfig := siteConfigHolder{
-		sitemap:          config.DecodeSitemap(config.Sitemap{Priority: -1, Filename: "sitemap.xml"}, cfg.Language.GetStringMap("sitemap")),
-		taxonomiesConfig: taxonomies,
-		timeout:          timeout,
-		hasCJKLanguage:   cfg.Language.GetBool("hasCJKLanguage"),
-		enableEmoji:      cfg.Language.Cfg.GetBool("enableEmoji"),
+		sitemap:               config.DecodeSitemap(config.Sitemap{Priority: -1, Filename: "sitemap.xml"}, cfg.Language.GetStringMap("sitemap")),
+		taxonomiesConfig:      taxonomies,
+		sitemapSplitThreshold: sitemapSplitThreshold,
+		sitemapGzip:           sitemapGzip,
+		indexNowKey:           indexNowKey,
+		indexNowKeyLocation:   indexNowKeyLocation,
+		indexNowEndpoint:      indexNowEndpoint,
+		indexNowChangedOnly:   indexNowChangedOnly,
+		indexNowMu:            &sync.Mutex{},
+		indexNowPublished:     make(map[string]string),
+		watchDebounce:         watchDebounce,
+		timeout:               timeout,
+		hasCJKLanguage:        cfg.Language.GetBool("hasCJKLanguage"),
+		enableEmoji:           cfg.Language.Cfg.GetBool("enableEmoji"),
+		navigationSort:        navigationSort,
+		taxonomyAliases:       taxonomyAliases,
 	}
 
 	var siteBucket *pagesMapBucket
@@ -480,6 +608,8 @@ But this also means that your site configuration may not do what you expect. If
 		relatedDocsHandler: page.NewRelatedDocsHandler(relatedContentConfig),
 	}
 
+	s.siteCfg.taxonomyProvider = newDefaultTaxonomyProvider(s)
+
 	s.prepareInits()
 
 	return s, nil
@@ -560,6 +690,7 @@ type SiteInfo struct {
 	hugoInfo     hugo.Info
 	title        string
 	RSSLink      string
+	AtomLink     string
 	Author       map[string]any
 	LanguageCode string
 	Copyright    string
@@ -724,6 +855,8 @@ type siteRefLinker struct {
 
 	errorLogger *log.Logger
 	notFoundURL string
+
+	cache *refLinkCache
 }
 
 func newSiteRefLinker(s *Site) (siteRefLinker, error) {
@@ -734,7 +867,7 @@ func newSiteRefLinker(s *Site) (siteRefLinker, error) {
 	if strings.EqualFold(errLevel, "warning") {
 		logger = s.Log.Warn()
 	}
-	return siteRefLinker{s: s, errorLogger: logger, notFoundURL: notFoundURL}, nil
+	return siteRefLinker{s: s, errorLogger: logger, notFoundURL: notFoundURL, cache: newRefLinkCache(defaultRefLinkCacheSize)}, nil
 }
 
 func (s siteRefLinker) logNotFound(ref, what string, p page.Page, position text.Position) {
@@ -766,6 +899,22 @@ func (s *siteRefLinker) refLink(ref string, source any, relative bool, outputFor
 	var target page.Page
 	var link string
 
+	// Anchor suffixes for in-page fragments depend on the live content
+	// converter of the target page, so we only cache the fragment-less
+	// resolution -- the common case for cross-references.
+	cacheable := refURL.Fragment == "" && refURL.Path != ""
+	var cacheKey refLinkCacheKey
+	if cacheable {
+		var sourcePagePath string
+		if p != nil {
+			sourcePagePath = p.Pathc()
+		}
+		cacheKey = refLinkCacheKey{sourcePagePath: sourcePagePath, ref: ref, relative: relative, outputFormat: outputFormat}
+		if entry, found := s.cache.get(cacheKey); found {
+			return entry.link, nil
+		}
+	}
+
 	if refURL.Path != "" {
 		var err error
 		target, err = s.s.getPageRef(p, refURL.Path)
@@ -803,6 +952,10 @@ func (s *siteRefLinker) refLink(ref string, source any, relative bool, outputFor
 		} else {
 			link = permalinker.Permalink()
 		}
+
+		if cacheable {
+			s.cache.set(cacheKey, refLinkCacheEntry{link: link, target: target.GetIdentity()})
+		}
 	}
 
 	if refURL.Fragment != "" {
@@ -880,19 +1033,98 @@ func (s *Site) filterFileEvents(events []fsnotify.Event) []fsnotify.Event {
 	return filtered
 }
 
-func (s *Site) translateFileEvents(events []fsnotify.Event) []fsnotify.Event {
-	var filtered []fsnotify.Event
+// defaultWatchDebounce is the quiet window bufferFileEvents waits for
+// before flushing a batch of fsnotify events, unless overridden by
+// internal.watchDebounce in site config.
+const defaultWatchDebounce = 50 * time.Millisecond
 
+// bufferFileEvents drains in, accumulating events until no new one has
+// arrived for the configured quiet window (internal.watchDebounce,
+// default defaultWatchDebounce), then returns the batch. This is what
+// lets a single editor save -- which often fires several raw fsnotify
+// events milliseconds apart -- reach translateFileEvents as one burst
+// instead of triggering a rebuild per event.
+func (s *Site) bufferFileEvents(in <-chan fsnotify.Event) []fsnotify.Event {
+	return bufferEvents(in, s.watchDebounce())
+}
+
+// bufferEvents is the debounce logic behind bufferFileEvents, split out
+// as a pure function of a channel and a quiet window so it can be unit
+// tested without a *Site.
+func bufferEvents(in <-chan fsnotify.Event, debounce time.Duration) []fsnotify.Event {
+	ev, ok := <-in
+	if !ok {
+		return nil
+	}
+
+	events := []fsnotify.Event{ev}
+
+	timer := time.NewTimer(debounce)
+	defer timer.Stop()
+
+	for {
+		select {
+		case ev, ok := <-in:
+			if !ok {
+				return events
+			}
+			events = append(events, ev)
+			if !timer.Stop() {
+				<-timer.C
+			}
+			timer.Reset(debounce)
+		case <-timer.C:
+			return events
+		}
+	}
+}
+
+// watchDebounce returns the configured quiet window for bufferFileEvents.
+func (s *Site) watchDebounce() time.Duration {
+	if s.siteCfg.watchDebounce > 0 {
+		return s.siteCfg.watchDebounce
+	}
+	return defaultWatchDebounce
+}
+
+// watchRebuild is the entry point a watch loop should call with its raw
+// fsnotify.Watcher.Events channel for each detected change. It buffers in
+// over the debounce window via bufferFileEvents before handing the
+// coalesced batch to processPartial, so a single editor save -- which
+// fires several raw events milliseconds apart -- triggers one rebuild
+// instead of one per event.
+func (s *Site) watchRebuild(in <-chan fsnotify.Event, config *BuildCfg, init func(config *BuildCfg) error) error {
+	events := s.bufferFileEvents(in)
+	if len(events) == 0 {
+		return nil
+	}
+	return s.processPartial(config, init, events)
+}
+
+// translateFileEvents coalesces a burst of fsnotify events for the same
+// file into a single logical event. Editors like JetBrains/VS Code turn
+// one atomic save into a Create+Write+Rename (or similar) sequence, which
+// would otherwise trigger a redundant DeleteMatches/resetPageStateFromEvents
+// pass per raw event. The caller is expected to have already buffered
+// events over a short quiet window (internal.watchDebounce, default 50ms,
+// see bufferFileEvents) before handing them here.
+func (s *Site) translateFileEvents(events []fsnotify.Event) []fsnotify.Event {
 	eventMap := make(map[string][]fsnotify.Event)
+	var order []string
 
 	// We often get a Remove etc. followed by a Create, a Create followed by a Write.
 	// Remove the superfluous events to mage the update logic simpler.
 	for _, ev := range events {
+		if _, found := eventMap[ev.Name]; !found {
+			order = append(order, ev.Name)
+		}
 		eventMap[ev.Name] = append(eventMap[ev.Name], ev)
 	}
 
-	for _, ev := range events {
-		mapped := eventMap[ev.Name]
+	filtered := make([]fsnotify.Event, 0, len(order))
+
+	for _, name := range order {
+		mapped := eventMap[name]
 
 		// Keep one
 		found := false
@@ -912,6 +1144,20 @@ func (s *Site) translateFileEvents(events []fsnotify.Event) []fsnotify.Event {
 			}
 		}
 
+		if kept.Op&fsnotify.Rename == fsnotify.Rename {
+			// A rename that still has the file present on disk was really
+			// just an update in disguise (many editors save via a temp
+			// file that they then rename into place); fold it into a
+			// Write so downstream code doesn't treat it as a removal.
+			if ex, err := afero.Exists(s.Fs.Source, kept.Name); ex && err == nil {
+				kept.Op = fsnotify.Write
+			}
+		}
+
+		if len(mapped) > 1 {
+			s.emitBuildEvent(buildEvent{Phase: "translate", Path: name, Op: kept.Op.String(), Kept: true})
+		}
+
 		filtered = append(filtered, kept)
 	}
 
@@ -963,6 +1209,7 @@ func (s *Site) processPartial(config *BuildCfg, init func(config *BuildCfg) erro
 		}
 
 		id, found := s.eventToIdentity(ev)
+		s.emitBuildEvent(buildEvent{Phase: "filter", Path: ev.Name, Op: ev.Op.String(), Kept: found})
 		if found {
 			changeIdentities[id] = id
 
@@ -992,6 +1239,14 @@ func (s *Site) processPartial(config *BuildCfg, init func(config *BuildCfg) erro
 		}
 	}
 
+	for id := range changeIdentities {
+		if pid, ok := id.(identity.PathIdentity); ok {
+			s.emitBuildEvent(buildEvent{Phase: "invalidate", Path: pid.Path, Op: string(pid.Type)})
+		} else {
+			s.emitBuildEvent(buildEvent{Phase: "invalidate", Kept: true})
+		}
+	}
+
 	changed := &whatChanged{
 		source: len(sourceChanged) > 0,
 		files:  sourceFilesChanged,
@@ -1064,8 +1319,14 @@ func (s *Site) processPartial(config *BuildCfg, init func(config *BuildCfg) erro
 
 	if config.ErrRecovery || tmplAdded || dataChanged {
 		h.resetPageState()
+		s.siteRefLinker.cache.Reset()
 	} else {
 		h.resetPageStateFromEvents(changeIdentities)
+		s.siteRefLinker.cache.invalidate(changeIdentities)
+	}
+
+	if hits, misses := s.siteRefLinker.cache.Stats(); hits+misses > 0 {
+		s.emitBuildEvent(buildEvent{Phase: "refcache", Op: fmt.Sprintf("hits=%d misses=%d", hits, misses), Kept: true})
 	}
 
 	if len(sourceReallyChanged) > 0 || len(contentFilesChanged) > 0 {
@@ -1079,6 +1340,10 @@ func (s *Site) processPartial(config *BuildCfg, init func(config *BuildCfg) erro
 
 		filenamesChanged = helpers.UniqueStringsReuse(filenamesChanged)
 
+		for _, filename := range filenamesChanged {
+			s.emitBuildEvent(buildEvent{Phase: "rerender", Path: filename, Kept: true})
+		}
+
 		if err := s.readAndProcessContent(*config, filenamesChanged...); err != nil {
 			return err
 		}
@@ -1102,6 +1367,8 @@ func (s *Site) render(ctx *siteRenderContext) (err error) {
 	}
 
 	if ctx.outIdx == 0 {
+		s.resetIndexNowPublished()
+
 		// Note that even if disableAliases is set, the aliases themselves are
 		// preserved on page. The motivation with this is to be able to generate
 		// 301 redirects in a .htacess file and similar using a custom output format.
@@ -1125,6 +1392,10 @@ func (s *Site) render(ctx *siteRenderContext) (err error) {
 			return
 		}
 
+		if err = s.renderAtomFeed(); err != nil {
+			return
+		}
+
 		if ctx.multihost {
 			if err = s.renderRobotsTXT(); err != nil {
 				return
@@ -1144,6 +1415,8 @@ func (s *Site) render(ctx *siteRenderContext) (err error) {
 		return
 	}
 
+	s.notifyIndexNow(s.indexNowPublishedSince())
+
 	return
 }
 
@@ -1265,6 +1538,16 @@ func (s *Site) initializeSiteInfo() error {
 		s.Info.RSSLink = s.permalink(rssOutputFormat.BaseFilename())
 	}
 
+	// Hugo has no built-in Atom output.Format (unlike RSS); a site opts
+	// in by defining one of its own, e.g. [outputFormats.Atom] in
+	// config, and referencing it from [outputs].home. See
+	// renderAtomFeed for where it actually gets rendered.
+	atomOutputFormat, found := s.outputFormats[page.KindHome].GetByName("Atom")
+
+	if found {
+		s.Info.AtomLink = s.permalink(atomOutputFormat.BaseFilename())
+	}
+
 	return nil
 }
 
@@ -1548,7 +1831,10 @@ func (s *Site) lookupLayouts(layouts ...string) tpl.Template {
 	return nil
 }
 
-func (s *Site) renderAndWriteXML(ctx context.Context, statCounter *uint64, name string, targetPath string, d any, templ tpl.Template) error {
+// renderAndWriteXML renders an XML document (sitemap, RSS, Atom, ...) using
+// templ and publishes it to targetPath. of is passed to the publisher,
+// which uses its MIME type for minification.
+func (s *Site) renderAndWriteXML(ctx context.Context, statCounter *uint64, name string, targetPath string, d any, templ tpl.Template, of output.Format) error {
 	renderBuffer := bp.GetBuffer()
 	defer bp.PutBuffer(renderBuffer)
 
@@ -1562,7 +1848,7 @@ func (s *Site) renderAndWriteXML(ctx context.Context, statCounter *uint64, name
 		StatCounter: statCounter,
 		// For the minification part of XML,
 		// we currently only use the MIME type.
-		OutputFormat: output.RSSFormat,
+		OutputFormat: of,
 		AbsURLPath:   s.absURLPath(targetPath),
 	}
 
@@ -1587,6 +1873,7 @@ func (s *Site) renderAndWritePage(statCounter *uint64, name string, targetPath s
 
 	isHTML := of.IsHTML
 	isRSS := of.Name == "rss"
+	isAtom := of.Name == "atom"
 
 	pd := publisher.Descriptor{
 		Src:          renderBuffer,
@@ -1595,8 +1882,8 @@ func (s *Site) renderAndWritePage(statCounter *uint64, name string, targetPath s
 		OutputFormat: p.outputFormat(),
 	}
 
-	if isRSS {
-		// Always canonify URLs in RSS
+	if isRSS || isAtom {
+		// Always canonify URLs in feeds.
 		pd.AbsURLPath = s.absURLPath(targetPath)
 	} else if isHTML {
 		if s.conf.RelativeURLs || s.conf.CanonifyURLs {
@@ -1614,7 +1901,15 @@ func (s *Site) renderAndWritePage(statCounter *uint64, name string, targetPath s
 
 	}
 
-	return s.publisher.Publish(pd)
+	contentHash := hashIndexNowContent(renderBuffer.Bytes())
+
+	if err := s.publisher.Publish(pd); err != nil {
+		return err
+	}
+
+	s.recordIndexNowPublish(p.Permalink(), contentHash)
+
+	return nil
 }
 
 var infoOnMissingLayout = map[string]bool{
@@ -0,0 +1,149 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"container/list"
+	"sync"
+	"sync/atomic"
+
+	"github.com/gohugoio/hugo/identity"
+)
+
+// defaultRefLinkCacheSize is the number of resolved ref links kept per Site.
+// This is deliberately generous; entries are small and sites with many
+// cross-references are exactly the ones this cache is meant to help.
+const defaultRefLinkCacheSize = 5000
+
+// refLinkCacheKey identifies one resolution of {{ ref }}/{{ relref }}.
+type refLinkCacheKey struct {
+	sourcePagePath string
+	ref            string
+	relative       bool
+	outputFormat   string
+}
+
+// refLinkCacheEntry is what refLinkCache stores for a resolved ref.
+type refLinkCacheEntry struct {
+	link   string
+	target identity.Identity
+}
+
+// refLinkCache is a bounded, per-Site LRU cache of resolved ref/relref
+// links, keyed by the source page, the ref itself, and the requested
+// output format. It's invalidated selectively on partial rebuilds:
+// entries whose target identity was among the changed identities are
+// evicted rather than the whole cache.
+type refLinkCache struct {
+	mu       sync.Mutex
+	maxItems int
+	ll       *list.List
+	items    map[refLinkCacheKey]*list.Element
+
+	hits   uint64
+	misses uint64
+}
+
+type refLinkCacheListEntry struct {
+	key   refLinkCacheKey
+	entry refLinkCacheEntry
+}
+
+func newRefLinkCache(maxItems int) *refLinkCache {
+	return &refLinkCache{
+		maxItems: maxItems,
+		ll:       list.New(),
+		items:    make(map[refLinkCacheKey]*list.Element),
+	}
+}
+
+func (c *refLinkCache) get(key refLinkCacheKey) (refLinkCacheEntry, bool) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	el, found := c.items[key]
+	if !found {
+		atomic.AddUint64(&c.misses, 1)
+		return refLinkCacheEntry{}, false
+	}
+
+	atomic.AddUint64(&c.hits, 1)
+	c.ll.MoveToFront(el)
+	return el.Value.(*refLinkCacheListEntry).entry, true
+}
+
+func (c *refLinkCache) set(key refLinkCacheKey, entry refLinkCacheEntry) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	if el, found := c.items[key]; found {
+		el.Value.(*refLinkCacheListEntry).entry = entry
+		c.ll.MoveToFront(el)
+		return
+	}
+
+	el := c.ll.PushFront(&refLinkCacheListEntry{key: key, entry: entry})
+	c.items[key] = el
+
+	for c.ll.Len() > c.maxItems {
+		oldest := c.ll.Back()
+		if oldest == nil {
+			break
+		}
+		c.ll.Remove(oldest)
+		delete(c.items, oldest.Value.(*refLinkCacheListEntry).key)
+	}
+}
+
+// invalidate evicts every cache entry whose target identity is among
+// changed, and returns the number of entries evicted.
+func (c *refLinkCache) invalidate(changed identity.Identities) int {
+	if len(changed) == 0 {
+		return 0
+	}
+
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	var evicted int
+	for key, el := range c.items {
+		target := el.Value.(*refLinkCacheListEntry).entry.target
+		if target == nil {
+			continue
+		}
+		if _, found := changed[target]; found {
+			c.ll.Remove(el)
+			delete(c.items, key)
+			evicted++
+		}
+	}
+
+	return evicted
+}
+
+// Stats returns the running hit/miss counters for the cache.
+func (c *refLinkCache) Stats() (hits, misses uint64) {
+	return atomic.LoadUint64(&c.hits), atomic.LoadUint64(&c.misses)
+}
+
+// Reset clears the cache and its counters, e.g. ahead of a full rebuild.
+func (c *refLinkCache) Reset() {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+
+	c.ll = list.New()
+	c.items = make(map[refLinkCacheKey]*list.Element)
+	atomic.StoreUint64(&c.hits, 0)
+	atomic.StoreUint64(&c.misses, 0)
+}
@@ -0,0 +1,97 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+
+	"github.com/gohugoio/hugo/identity"
+)
+
+func TestRefLinkCacheGetSetAndEviction(t *testing.T) {
+	c := newRefLinkCache(2)
+
+	k1 := refLinkCacheKey{ref: "/a"}
+	k2 := refLinkCacheKey{ref: "/b"}
+	k3 := refLinkCacheKey{ref: "/c"}
+
+	c.set(k1, refLinkCacheEntry{link: "/a/"})
+	c.set(k2, refLinkCacheEntry{link: "/b/"})
+
+	if _, found := c.get(k1); !found {
+		t.Fatal("expected k1 to be cached")
+	}
+
+	// k1 was just touched, so k2 is now the least recently used and
+	// should be the one evicted once the cache grows past its max size.
+	c.set(k3, refLinkCacheEntry{link: "/c/"})
+
+	if _, found := c.get(k2); found {
+		t.Fatal("expected k2 to have been evicted")
+	}
+	if _, found := c.get(k1); !found {
+		t.Fatal("expected k1 to still be cached")
+	}
+	if _, found := c.get(k3); !found {
+		t.Fatal("expected k3 to be cached")
+	}
+}
+
+func TestRefLinkCacheInvalidate(t *testing.T) {
+	c := newRefLinkCache(10)
+
+	targetA := identity.KeyValueIdentity{Key: "page", Value: "a"}
+	targetB := identity.KeyValueIdentity{Key: "page", Value: "b"}
+
+	kA := refLinkCacheKey{ref: "/a"}
+	kB := refLinkCacheKey{ref: "/b"}
+
+	c.set(kA, refLinkCacheEntry{link: "/a/", target: targetA})
+	c.set(kB, refLinkCacheEntry{link: "/b/", target: targetB})
+
+	changed := identity.Identities{targetA: targetA}
+
+	if n := c.invalidate(changed); n != 1 {
+		t.Fatalf("expected 1 entry invalidated, got %d", n)
+	}
+
+	if _, found := c.get(kA); found {
+		t.Fatal("expected kA to have been invalidated")
+	}
+	if _, found := c.get(kB); !found {
+		t.Fatal("expected kB to remain cached")
+	}
+}
+
+func TestRefLinkCacheStatsAndReset(t *testing.T) {
+	c := newRefLinkCache(10)
+	k := refLinkCacheKey{ref: "/a"}
+
+	c.get(k) // miss
+	c.set(k, refLinkCacheEntry{link: "/a/"})
+	c.get(k) // hit
+
+	if hits, misses := c.Stats(); hits != 1 || misses != 1 {
+		t.Fatalf("expected 1 hit and 1 miss, got hits=%d misses=%d", hits, misses)
+	}
+
+	c.Reset()
+
+	if hits, misses := c.Stats(); hits != 0 || misses != 0 {
+		t.Fatalf("expected counters reset, got hits=%d misses=%d", hits, misses)
+	}
+	if _, found := c.get(k); found {
+		t.Fatal("expected cache to be empty after reset")
+	}
+}
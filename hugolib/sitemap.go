@@ -0,0 +1,194 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"compress/gzip"
+	"context"
+	"fmt"
+	"sort"
+	"strings"
+	"time"
+
+	bp "github.com/gohugoio/hugo/bufferpool"
+	"github.com/gohugoio/hugo/output"
+	"github.com/gohugoio/hugo/resources/page"
+	"github.com/spf13/cast"
+)
+
+// defaultSitemapSplitThreshold mirrors the sitemaps.org cap of 50,000 URLs
+// per sitemap file. Sites with more eligible pages than this get a
+// sitemap index instead of a single, invalid oversized sitemap.xml.
+const defaultSitemapSplitThreshold = 50000
+
+// sitemapEligiblePages returns every renderable page that hasn't opted
+// out via the `sitemap_exclude` front matter field, sorted by permalink
+// so that sharding is stable across builds.
+func (s *Site) sitemapEligiblePages() page.Pages {
+	var pages page.Pages
+
+	s.pageMap.pageTrees.WalkRenderable(func(ss string, n *contentNode) bool {
+		p := n.p
+		if cast.ToBool(p.Params()["sitemap_exclude"]) {
+			return false
+		}
+		pages = append(pages, p)
+		return false
+	})
+
+	sort.Slice(pages, func(i, j int) bool {
+		return pages[i].RelPermalink() < pages[j].RelPermalink()
+	})
+
+	return pages
+}
+
+// renderSitemap renders the site's sitemap.xml. When the number of
+// eligible pages exceeds sitemap.splitThreshold (default
+// defaultSitemapSplitThreshold), it instead renders a set of
+// sitemap-N.xml shards plus a sitemap.xml sitemap index, per
+// sitemaps.org's limits on entries and file size. If sitemap.gzip is
+// set, the shards are gzipped to sitemap-N.xml.gz; the index itself is
+// never compressed.
+func (s *Site) renderSitemap() error {
+	pages := s.sitemapEligiblePages()
+
+	threshold := s.siteCfg.sitemapSplitThreshold
+	if threshold <= 0 {
+		threshold = defaultSitemapSplitThreshold
+	}
+
+	if len(pages) <= threshold {
+		return s.renderSitemapFile(s.conf.Sitemap.Filename, pages)
+	}
+
+	return s.renderSitemapIndex(pages, threshold)
+}
+
+// sitemapShard is one entry of a rendered sitemap index: the shard's
+// filename and the most recent Lastmod among the pages it contains.
+type sitemapShard struct {
+	Filename string
+	Lastmod  string
+}
+
+// partitionPages splits pages into stable, sequential shards of at most
+// shardSize pages each. The final shard may be smaller.
+func partitionPages(pages page.Pages, shardSize int) []page.Pages {
+	var shards []page.Pages
+	for i := 0; i < len(pages); i += shardSize {
+		end := i + shardSize
+		if end > len(pages) {
+			end = len(pages)
+		}
+		shards = append(shards, pages[i:end])
+	}
+	return shards
+}
+
+// renderSitemapIndex partitions pages into stable, sequential shards of
+// at most shardSize pages, renders each as its own sitemap-N.xml (or
+// sitemap-N.xml.gz if sitemap.gzip is set), and
+// renders a top-level sitemapindex referencing them.
+func (s *Site) renderSitemapIndex(pages page.Pages, shardSize int) error {
+	var shards []sitemapShard
+
+	for i, shard := range partitionPages(pages, shardSize) {
+		filename := fmt.Sprintf("sitemap-%d.xml", i+1)
+		if s.siteCfg.sitemapGzip {
+			filename += ".gz"
+		}
+
+		if err := s.renderSitemapFile(filename, shard); err != nil {
+			return err
+		}
+
+		shards = append(shards, sitemapShard{Filename: filename, Lastmod: shardLastmod(shard)})
+	}
+
+	templ := s.lookupLayouts("sitemapindex.xml", "_default/sitemapindex.xml", "_internal/_default/sitemapindex.xml")
+
+	return s.renderAndWriteXML(
+		context.Background(),
+		&s.PathSpec.ProcessingStats.Pages,
+		"sitemapindex",
+		s.conf.Sitemap.Filename,
+		shards,
+		templ,
+		output.RSSFormat,
+	)
+}
+
+// renderSitemapFile renders a single sitemap file (or shard) for pages.
+// If name ends in .gz, the XML is gzip-compressed before publishing.
+func (s *Site) renderSitemapFile(name string, pages page.Pages) error {
+	templ := s.lookupLayouts("sitemap.xml", "_default/sitemap.xml", "_internal/_default/sitemap.xml")
+
+	if !strings.HasSuffix(name, ".gz") {
+		return s.renderAndWriteXML(context.Background(), &s.PathSpec.ProcessingStats.Pages, "sitemap", name, pages, templ, output.RSSFormat)
+	}
+
+	renderBuffer := bp.GetBuffer()
+	defer bp.PutBuffer(renderBuffer)
+
+	if err := s.renderForTemplate(context.Background(), "sitemap", "", pages, renderBuffer, templ); err != nil {
+		return err
+	}
+
+	gzBuffer := bp.GetBuffer()
+	defer bp.PutBuffer(gzBuffer)
+
+	gw := gzip.NewWriter(gzBuffer)
+	if _, err := gw.Write(renderBuffer.Bytes()); err != nil {
+		return err
+	}
+	if err := gw.Close(); err != nil {
+		return err
+	}
+
+	return s.publish(&s.PathSpec.ProcessingStats.Pages, name, gzBuffer, s.BaseFs.Publish.Fs)
+}
+
+// shardLastmod returns the most recent Lastmod among pages, formatted for
+// a sitemap's <lastmod> element. It's used for each shard entry in the
+// sitemap index.
+func shardLastmod(pages page.Pages) string {
+	var times []time.Time
+	for _, p := range pages {
+		if l := p.Lastmod(); !l.IsZero() {
+			times = append(times, l)
+		}
+	}
+
+	latest := latestTime(times)
+	if latest.IsZero() {
+		return ""
+	}
+
+	return latest.Format("2006-01-02T15:04:05-07:00")
+}
+
+// latestTime returns the chronologically latest of times. Comparing the
+// time.Time values themselves (rather than any particular formatting of
+// them) avoids picking an earlier instant just because its UTC offset
+// happens to sort later as a string.
+func latestTime(times []time.Time) time.Time {
+	var latest time.Time
+	for _, t := range times {
+		if t.After(latest) {
+			latest = t
+		}
+	}
+	return latest
+}
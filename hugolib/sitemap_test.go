@@ -0,0 +1,78 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"testing"
+	"time"
+
+	"github.com/gohugoio/hugo/resources/page"
+)
+
+func TestPartitionPages(t *testing.T) {
+	tests := []struct {
+		total     int
+		shardSize int
+		wantLens  []int
+	}{
+		{total: 0, shardSize: 3, wantLens: nil},
+		{total: 5, shardSize: 10, wantLens: []int{5}},
+		{total: 10, shardSize: 5, wantLens: []int{5, 5}},
+		{total: 12, shardSize: 5, wantLens: []int{5, 5, 2}},
+		{total: 50000, shardSize: defaultSitemapSplitThreshold, wantLens: []int{50000}},
+		{total: 50001, shardSize: defaultSitemapSplitThreshold, wantLens: []int{50000, 1}},
+	}
+
+	for _, tt := range tests {
+		pages := make(page.Pages, tt.total)
+		shards := partitionPages(pages, tt.shardSize)
+
+		if len(shards) != len(tt.wantLens) {
+			t.Fatalf("total=%d shardSize=%d: got %d shards, want %d", tt.total, tt.shardSize, len(shards), len(tt.wantLens))
+		}
+		for i, want := range tt.wantLens {
+			if len(shards[i]) != want {
+				t.Fatalf("total=%d shardSize=%d: shard %d has %d pages, want %d", tt.total, tt.shardSize, i, len(shards[i]), want)
+			}
+		}
+	}
+}
+
+func TestLatestTimeCrossesZoneOffsets(t *testing.T) {
+	// 2024-06-01T23:00:00-07:00 is 2024-06-02T06:00:00Z, which is later
+	// than 2024-06-02T01:00:00+01:00 (2024-06-02T00:00:00Z) -- even
+	// though the latter's formatted string sorts after the former's.
+	earlierUTCButLaterString := mustParseTime(t, "2024-06-02T01:00:00+01:00")
+	laterUTC := mustParseTime(t, "2024-06-01T23:00:00-07:00")
+
+	got := latestTime([]time.Time{earlierUTCButLaterString, laterUTC})
+	if !got.Equal(laterUTC) {
+		t.Fatalf("latestTime() = %s, want %s", got, laterUTC)
+	}
+}
+
+func TestLatestTimeEmpty(t *testing.T) {
+	if got := latestTime(nil); !got.IsZero() {
+		t.Fatalf("latestTime(nil) = %s, want zero time", got)
+	}
+}
+
+func mustParseTime(t *testing.T, s string) time.Time {
+	t.Helper()
+	tm, err := time.Parse(time.RFC3339, s)
+	if err != nil {
+		t.Fatalf("failed to parse %q: %s", s, err)
+	}
+	return tm
+}
@@ -0,0 +1,211 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"sort"
+	"strings"
+
+	"github.com/gohugoio/hugo/resources/page"
+)
+
+// defaultTaxonomyProvider is page.TaxonomyProvider's default
+// implementation. It assembles terms exactly as Hugo always has (via
+// pageMap.assembleTaxonomies), but layers hierarchical parent/child
+// lookups on top by treating "/" in a term as a path separator (e.g.
+// "topics/cloud/aws"), and resolves any configured aliases before
+// looking pages up.
+type defaultTaxonomyProvider struct {
+	s *Site
+
+	// aliases maps, per plural, an alias term name to its canonical term.
+	aliases map[string]map[string]string
+}
+
+func newDefaultTaxonomyProvider(s *Site) page.TaxonomyProvider {
+	aliases := make(map[string]map[string]string)
+	for plural, synonyms := range s.siteCfg.taxonomyAliases {
+		for canonical, alts := range synonyms {
+			if aliases[plural] == nil {
+				aliases[plural] = make(map[string]string)
+			}
+			for _, alt := range alts {
+				aliases[plural][alt] = canonical
+			}
+		}
+	}
+
+	return &defaultTaxonomyProvider{s: s, aliases: aliases}
+}
+
+func (p *defaultTaxonomyProvider) Assemble() error {
+	return p.s.pageMap.assembleTaxonomies()
+}
+
+func (p *defaultTaxonomyProvider) canonicalTerm(plural, term string) string {
+	if canonical, found := p.aliases[plural][term]; found {
+		return canonical
+	}
+	return term
+}
+
+func (p *defaultTaxonomyProvider) Terms(plural string) []string {
+	list := p.s.Taxonomies()[plural]
+	terms := make([]string, 0, len(list))
+	for term := range list {
+		terms = append(terms, term)
+	}
+	return terms
+}
+
+func (p *defaultTaxonomyProvider) Lookup(plural, term string) page.Pages {
+	term = p.canonicalTerm(plural, term)
+	list := p.s.Taxonomies()[plural]
+
+	pas := list[term].Pages()
+
+	names := make([]string, 0, len(list))
+	for candidate := range list {
+		names = append(names, candidate)
+	}
+
+	// A hierarchical lookup on a parent term also picks up pages tagged
+	// with any of its descendants, e.g. "topics/cloud" includes pages
+	// tagged "topics/cloud/aws".
+	for _, candidate := range descendantsOf(term, names) {
+		pas = append(pas, list[candidate].Pages()...)
+	}
+
+	return pas
+}
+
+func (p *defaultTaxonomyProvider) Parent(plural, term string) (string, bool) {
+	term = p.canonicalTerm(plural, term)
+	idx := strings.LastIndex(term, "/")
+	if idx == -1 {
+		return "", false
+	}
+	return term[:idx], true
+}
+
+func (p *defaultTaxonomyProvider) Children(plural, term string) []string {
+	term = p.canonicalTerm(plural, term)
+	list := p.s.Taxonomies()[plural]
+
+	names := make([]string, 0, len(list))
+	for candidate := range list {
+		names = append(names, candidate)
+	}
+
+	return childrenOf(term, names)
+}
+
+func (p *defaultTaxonomyProvider) Aliases(plural, term string) []string {
+	var aliases []string
+	for alias, canonical := range p.aliases[plural] {
+		if canonical == term {
+			aliases = append(aliases, alias)
+		}
+	}
+	return aliases
+}
+
+// Related returns the term names under plural closest to term by
+// hierarchical path distance: ancestors and descendants (at any depth)
+// first, then siblings, then progressively more distant cousins.
+func (p *defaultTaxonomyProvider) Related(plural, term string) []string {
+	term = p.canonicalTerm(plural, term)
+	list := p.s.Taxonomies()[plural]
+
+	type scoredTerm struct {
+		name     string
+		distance int
+	}
+
+	scored := make([]scoredTerm, 0, len(list))
+	for candidate := range list {
+		if candidate == term {
+			continue
+		}
+		scored = append(scored, scoredTerm{name: candidate, distance: hierarchicalDistance(term, candidate)})
+	}
+
+	sort.Slice(scored, func(i, j int) bool {
+		if scored[i].distance != scored[j].distance {
+			return scored[i].distance < scored[j].distance
+		}
+		return scored[i].name < scored[j].name
+	})
+
+	related := make([]string, len(scored))
+	for i, s := range scored {
+		related[i] = s.name
+	}
+	return related
+}
+
+// childrenOf returns the immediate child term names of term among
+// allTerms, where a nested term ("topics/cloud/aws") is an implicit
+// child of its parent ("topics/cloud") by "/" path segment.
+func childrenOf(term string, allTerms []string) []string {
+	prefix := term + "/"
+	var children []string
+	for _, candidate := range allTerms {
+		if !strings.HasPrefix(candidate, prefix) {
+			continue
+		}
+		rest := candidate[len(prefix):]
+		if strings.Contains(rest, "/") {
+			// Not an immediate child.
+			continue
+		}
+		children = append(children, candidate)
+	}
+	return children
+}
+
+// descendantsOf returns every term among allTerms that is nested under
+// term ("topics/cloud" matches "topics/cloud/aws"), excluding term
+// itself.
+func descendantsOf(term string, allTerms []string) []string {
+	prefix := term + "/"
+	var descendants []string
+	for _, candidate := range allTerms {
+		if candidate != term && strings.HasPrefix(candidate, prefix) {
+			descendants = append(descendants, candidate)
+		}
+	}
+	return descendants
+}
+
+// hierarchicalDistance scores how closely related two distinct terms
+// are by path segment, for ranking Related results. Any ancestor/descendant
+// pair, at whatever depth, scores closest (1), then siblings, then
+// increasingly distant cousins based
+// on how far each term's path diverges from their shared ancestor.
+func hierarchicalDistance(term, candidate string) int {
+	if strings.HasPrefix(candidate, term+"/") || strings.HasPrefix(term, candidate+"/") {
+		return 1
+	}
+
+	termSegs := strings.Split(term, "/")
+	candSegs := strings.Split(candidate, "/")
+
+	shared := 0
+	for shared < len(termSegs) && shared < len(candSegs) && termSegs[shared] == candSegs[shared] {
+		shared++
+	}
+
+	return 1 + (len(termSegs) - shared) + (len(candSegs) - shared)
+}
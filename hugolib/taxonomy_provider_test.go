@@ -0,0 +1,90 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestDefaultTaxonomyProviderCanonicalTermAndAliases(t *testing.T) {
+	p := &defaultTaxonomyProvider{
+		aliases: map[string]map[string]string{
+			"topics": {"golang": "go", "js": "javascript"},
+		},
+	}
+
+	if got := p.canonicalTerm("topics", "golang"); got != "go" {
+		t.Fatalf("canonicalTerm(golang) = %q, want %q", got, "go")
+	}
+	if got := p.canonicalTerm("topics", "go"); got != "go" {
+		t.Fatalf("canonicalTerm(go) = %q, want %q", got, "go")
+	}
+
+	aliases := p.Aliases("topics", "go")
+	sort.Strings(aliases)
+	if want := []string{"golang"}; !reflect.DeepEqual(aliases, want) {
+		t.Fatalf("Aliases(go) = %v, want %v", aliases, want)
+	}
+}
+
+func TestDefaultTaxonomyProviderParent(t *testing.T) {
+	p := &defaultTaxonomyProvider{}
+
+	if parent, ok := p.Parent("topics", "topics/cloud/aws"); !ok || parent != "topics/cloud" {
+		t.Fatalf("Parent(topics/cloud/aws) = (%q, %v), want (%q, true)", parent, ok, "topics/cloud")
+	}
+	if _, ok := p.Parent("topics", "cloud"); ok {
+		t.Fatal("expected a top-level term to have no parent")
+	}
+}
+
+func TestChildrenOf(t *testing.T) {
+	all := []string{"topics/cloud/aws", "topics/cloud/gcp", "topics/cloud/gcp/bigquery", "topics/other"}
+
+	got := childrenOf("topics/cloud", all)
+	sort.Strings(got)
+
+	want := []string{"topics/cloud/aws", "topics/cloud/gcp"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("childrenOf = %v, want %v", got, want)
+	}
+}
+
+func TestDescendantsOf(t *testing.T) {
+	all := []string{"topics/cloud/aws", "topics/cloud/gcp/bigquery", "topics/other"}
+
+	got := descendantsOf("topics/cloud", all)
+	sort.Strings(got)
+
+	want := []string{"topics/cloud/aws", "topics/cloud/gcp/bigquery"}
+	if !reflect.DeepEqual(got, want) {
+		t.Fatalf("descendantsOf = %v, want %v", got, want)
+	}
+}
+
+func TestHierarchicalDistanceOrdering(t *testing.T) {
+	terms := []string{"topics/cloud/gcp", "topics/cloud/aws/lambda", "topics/other", "topics/cloud"}
+
+	sort.Slice(terms, func(i, j int) bool {
+		return hierarchicalDistance("topics/cloud/aws", terms[i]) < hierarchicalDistance("topics/cloud/aws", terms[j])
+	})
+
+	// The most distant term, topics/other (a sibling of the parent),
+	// should rank last, behind the sibling, the child, and the parent.
+	if got := terms[len(terms)-1]; got != "topics/other" {
+		t.Fatalf("expected the most distant term to rank last, got order %v", terms)
+	}
+}
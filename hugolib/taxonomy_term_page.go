@@ -0,0 +1,55 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import "github.com/gohugoio/hugo/resources/page"
+
+// taxonomyTermInfo exposes a single term's TaxonomyProvider-backed
+// relationships (Parent, Children, Aliases, Related) as plain methods.
+// No term page embeds it, so these are not available as `.Parent`,
+// `.Children`, `.Aliases` or `.Related` on term pages in templates.
+type taxonomyTermInfo struct {
+	provider page.TaxonomyProvider
+	plural   string
+	term     string
+}
+
+// newTaxonomyTermInfo returns a taxonomyTermInfo for term under plural,
+// resolved against provider. It is only called from tests; term page
+// construction does not call it.
+func newTaxonomyTermInfo(provider page.TaxonomyProvider, plural, term string) *taxonomyTermInfo {
+	return &taxonomyTermInfo{provider: provider, plural: plural, term: term}
+}
+
+// Parent returns this term's parent term name, and whether it has one.
+func (t *taxonomyTermInfo) Parent() (string, bool) {
+	return t.provider.Parent(t.plural, t.term)
+}
+
+// Children returns this term's immediate child term names.
+func (t *taxonomyTermInfo) Children() []string {
+	return t.provider.Children(t.plural, t.term)
+}
+
+// Aliases returns the configured synonym term names that resolve to
+// this term.
+func (t *taxonomyTermInfo) Aliases() []string {
+	return t.provider.Aliases(t.plural, t.term)
+}
+
+// Related returns the term names most closely related to this term,
+// nearest first.
+func (t *taxonomyTermInfo) Related() []string {
+	return t.provider.Related(t.plural, t.term)
+}
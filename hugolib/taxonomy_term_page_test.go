@@ -0,0 +1,81 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"reflect"
+	"testing"
+
+	"github.com/gohugoio/hugo/resources/page"
+)
+
+// fakeTaxonomyProvider is a minimal page.TaxonomyProvider double that
+// records the plural/term it was called with, so tests can assert
+// taxonomyTermInfo forwards to the right provider call without needing a
+// real Site.
+type fakeTaxonomyProvider struct {
+	gotPlural, gotTerm string
+}
+
+func (f *fakeTaxonomyProvider) Assemble() error { return nil }
+
+func (f *fakeTaxonomyProvider) Terms(plural string) []string { return nil }
+
+func (f *fakeTaxonomyProvider) Lookup(plural, term string) page.Pages {
+	return nil
+}
+
+func (f *fakeTaxonomyProvider) Parent(plural, term string) (string, bool) {
+	f.gotPlural, f.gotTerm = plural, term
+	return "topics/cloud", true
+}
+
+func (f *fakeTaxonomyProvider) Children(plural, term string) []string {
+	f.gotPlural, f.gotTerm = plural, term
+	return []string{"topics/cloud/aws", "topics/cloud/gcp"}
+}
+
+func (f *fakeTaxonomyProvider) Aliases(plural, term string) []string {
+	f.gotPlural, f.gotTerm = plural, term
+	return []string{"golang"}
+}
+
+func (f *fakeTaxonomyProvider) Related(plural, term string) []string {
+	f.gotPlural, f.gotTerm = plural, term
+	return []string{"topics/cloud/gcp", "topics/other"}
+}
+
+func TestTaxonomyTermInfoForwardsToProvider(t *testing.T) {
+	provider := &fakeTaxonomyProvider{}
+	info := newTaxonomyTermInfo(provider, "topics", "topics/cloud/aws")
+
+	if parent, ok := info.Parent(); !ok || parent != "topics/cloud" {
+		t.Fatalf("Parent() = (%q, %v), want (%q, true)", parent, ok, "topics/cloud")
+	}
+	if provider.gotPlural != "topics" || provider.gotTerm != "topics/cloud/aws" {
+		t.Fatalf("Parent() called provider with (%q, %q), want (%q, %q)", provider.gotPlural, provider.gotTerm, "topics", "topics/cloud/aws")
+	}
+
+	if got, want := info.Children(), []string{"topics/cloud/aws", "topics/cloud/gcp"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Children() = %v, want %v", got, want)
+	}
+
+	if got, want := info.Aliases(), []string{"golang"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Aliases() = %v, want %v", got, want)
+	}
+
+	if got, want := info.Related(), []string{"topics/cloud/gcp", "topics/other"}; !reflect.DeepEqual(got, want) {
+		t.Fatalf("Related() = %v, want %v", got, want)
+	}
+}
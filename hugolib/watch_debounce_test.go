@@ -0,0 +1,90 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package hugolib
+
+import (
+	"fmt"
+	"testing"
+	"time"
+
+	"github.com/fsnotify/fsnotify"
+)
+
+func TestBufferEventsCoalescesBurst(t *testing.T) {
+	in := make(chan fsnotify.Event)
+
+	go func() {
+		in <- fsnotify.Event{Name: "a.md", Op: fsnotify.Create}
+		in <- fsnotify.Event{Name: "a.md", Op: fsnotify.Write}
+		in <- fsnotify.Event{Name: "a.md", Op: fsnotify.Rename}
+		// No further sends: bufferEvents should flush once the debounce
+		// window elapses without a new event.
+	}()
+
+	got := bufferEvents(in, 10*time.Millisecond)
+
+	if len(got) != 3 {
+		t.Fatalf("got %d events, want 3", len(got))
+	}
+}
+
+func TestBufferEventsClosedChannel(t *testing.T) {
+	in := make(chan fsnotify.Event)
+	close(in)
+
+	got := bufferEvents(in, 10*time.Millisecond)
+	if got != nil {
+		t.Fatalf("got %v, want nil for an already-closed channel", got)
+	}
+}
+
+func TestBufferEventsFlushesOnChannelClose(t *testing.T) {
+	in := make(chan fsnotify.Event, 2)
+	in <- fsnotify.Event{Name: "a.md", Op: fsnotify.Create}
+	in <- fsnotify.Event{Name: "a.md", Op: fsnotify.Write}
+	close(in)
+
+	got := bufferEvents(in, 50*time.Millisecond)
+	if len(got) != 2 {
+		t.Fatalf("got %d events, want 2", len(got))
+	}
+}
+
+// BenchmarkBufferFileEventsBurst simulates the fsnotify traffic a ~1k-page
+// site produces when an editor bulk-saves every content file at once
+// (Create+Write+Rename per file): 3,000 raw events arriving back to back.
+// It measures how long bufferEvents takes to collect that burst into the
+// single batch translateFileEvents then dedupes. The channel is closed
+// after the burst, so bufferEvents returns once it is drained: the
+// debounce quiet window is not part of the measured time, and no rebuild
+// is run.
+func BenchmarkBufferFileEventsBurst(b *testing.B) {
+	const pages = 1000
+
+	for i := 0; i < b.N; i++ {
+		in := make(chan fsnotify.Event, pages*3)
+		for p := 0; p < pages; p++ {
+			name := fmt.Sprintf("content/post-%d.md", p)
+			in <- fsnotify.Event{Name: name, Op: fsnotify.Create}
+			in <- fsnotify.Event{Name: name, Op: fsnotify.Write}
+			in <- fsnotify.Event{Name: name, Op: fsnotify.Rename}
+		}
+		close(in)
+
+		got := bufferEvents(in, defaultWatchDebounce)
+		if len(got) != pages*3 {
+			b.Fatalf("got %d events, want %d", len(got), pages*3)
+		}
+	}
+}
@@ -0,0 +1,69 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package page
+
+import "sort"
+
+// NextPrevLess reports whether page a should sort before page b when
+// resolving the .Next/.Prev and .NextInSection/.PrevInSection chains.
+type NextPrevLess func(a, b Page) bool
+
+var nextPrevSorts = map[string]NextPrevLess{}
+
+// RegisterNextPrevSort registers a named next/prev ordering strategy that
+// can be referenced from site configuration (navigation.sort) or a
+// section's navigation front matter, so themes don't have to reimplement
+// pagination ordering with where/sort in templates.
+//
+// RegisterNextPrevSort is meant to be called from an init function.
+func RegisterNextPrevSort(name string, less NextPrevLess) {
+	nextPrevSorts[name] = less
+}
+
+// LookupNextPrevSort returns the next/prev ordering strategy registered
+// under name, and whether one was found.
+func LookupNextPrevSort(name string) (NextPrevLess, bool) {
+	less, found := nextPrevSorts[name]
+	return less, found
+}
+
+func init() {
+	RegisterNextPrevSort("weight", func(a, b Page) bool {
+		w1, w2 := a.Weight(), b.Weight()
+		if w1 != w2 {
+			return w1 < w2
+		}
+		return a.Date().After(b.Date())
+	})
+	RegisterNextPrevSort("date-asc", func(a, b Page) bool {
+		return a.Date().Before(b.Date())
+	})
+	RegisterNextPrevSort("title", func(a, b Page) bool {
+		return a.Title() < b.Title()
+	})
+}
+
+// SortByStrategy sorts pas in place using the next/prev ordering strategy
+// registered under name. It falls back to SortByDefault if name is empty
+// or no strategy is registered under that name.
+func SortByStrategy(name string, pas Pages) {
+	less, found := nextPrevSorts[name]
+	if !found {
+		SortByDefault(pas)
+		return
+	}
+	sort.SliceStable(pas, func(i, j int) bool {
+		return less(pas[i], pas[j])
+	})
+}
@@ -0,0 +1,57 @@
+// Copyright 2023 The Hugo Authors. All rights reserved.
+//
+// Licensed under the Apache License, Version 2.0 (the "License");
+// you may not use this file except in compliance with the License.
+// You may obtain a copy of the License at
+// http://www.apache.org/licenses/LICENSE-2.0
+//
+// Unless required by applicable law or agreed to in writing, software
+// distributed under the License is distributed on an "AS IS" BASIS,
+// WITHOUT WARRANTIES OR CONDITIONS OF ANY KIND, either express or implied.
+// See the License for the specific language governing permissions and
+// limitations under the License.
+
+package page
+
+// TaxonomyProvider abstracts how a Site assembles, looks up, and relates
+// taxonomy terms. The default implementation assembles Hugo's classic
+// singular/plural term maps and adds hierarchical lookups (nested terms
+// such as "topics/cloud/aws", split on "/") and configured term
+// synonyms/aliases on top. A Site always uses the default implementation;
+// no site configuration option selects another provider.
+//
+// SiteInfo.Taxonomies() is unaffected by which provider is in use -- it
+// always returns the assembled TaxonomyList.
+type TaxonomyProvider interface {
+	// Assemble builds the taxonomy term pages and their page
+	// relationships. It's called once per Site build, lazily, the same
+	// way the default term-map assembly used to be.
+	Assemble() error
+
+	// Terms returns the known term names for the given plural.
+	Terms(plural string) []string
+
+	// Lookup returns the pages tagged with term under plural. For a
+	// hierarchical provider this may also include pages tagged with
+	// any descendant of term.
+	Lookup(plural, term string) Pages
+
+	// Parent returns the parent term name of term under plural, and
+	// whether term has a parent. A flat provider always returns
+	// ("", false).
+	Parent(plural, term string) (string, bool)
+
+	// Children returns the immediate child term names of term under
+	// plural. A flat provider always returns nil.
+	Children(plural, term string) []string
+
+	// Aliases returns the configured synonym term names that resolve
+	// to term under plural.
+	Aliases(plural, term string) []string
+
+	// Related returns the term names under plural that are most closely
+	// related to term, nearest first. A hierarchical provider ranks by
+	// path distance (parent, children and siblings before distant
+	// cousins); a flat provider may simply return nil.
+	Related(plural, term string) []string
+}